@@ -0,0 +1,39 @@
+package gocloudclient
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffStrategy computes how long to wait before retry attempt n (0-based,
+// counting only retries, not the initial attempt).
+type BackoffStrategy interface {
+	Backoff(attempt int) time.Duration
+}
+
+// ExponentialBackoff doubles the delay on each attempt, capped at Max, and
+// applies full jitter (a random delay in [0, cappedDelay)) so that many
+// clients retrying the same failure don't all hammer the server at once.
+type ExponentialBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// NewExponentialBackoff creates an ExponentialBackoff starting at base and
+// never waiting longer than max between attempts.
+func NewExponentialBackoff(base, max time.Duration) *ExponentialBackoff {
+	return &ExponentialBackoff{Base: base, Max: max}
+}
+
+// Backoff implements BackoffStrategy.
+func (b *ExponentialBackoff) Backoff(attempt int) time.Duration {
+	delay := time.Duration(float64(b.Base) * math.Pow(2, float64(attempt)))
+	if delay <= 0 || delay > b.Max {
+		delay = b.Max
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(delay)))
+}
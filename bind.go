@@ -0,0 +1,241 @@
+package gocloudclient
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unmarshal binds this ConfigResponse's flattened properties into target,
+// a pointer to a struct, using `config:"..."` tags analogous to Spring's
+// @ConfigurationProperties.
+//
+// Tag format is `config:"dotted.key[,default=value][,required]"`. A field
+// whose tag names a prefix under which its own fields live (because the
+// field is itself a struct) recurses with that prefix prepended to its
+// fields' keys, e.g. a field tagged `config:"spring.datasource"` of type
+// DataSourceConfig resolves DataSourceConfig.Host from "spring.datasource.host".
+//
+// Supported field types are the Go scalars, time.Duration (via
+// time.ParseDuration), []string (comma-separated), and map[string]string.
+// Every key that fails to bind or is missing while marked `,required` is
+// collected and returned together as a single error.
+//
+// A value whose {cipher} prefix failed to decrypt is treated the same as
+// a missing key (see GetValue): a non-required field gets its `,default=`
+// value, or is left unset without one, and a `,required` field is reported
+// as a binding error rather than having the decrypt failure text silently
+// bound in its place.
+func (configResp *ConfigResponse) Unmarshal(target interface{}) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("gocloudclient: Unmarshal target must be a non-nil pointer to a struct")
+	}
+
+	properties := configResp.GetPropertySources()
+
+	var errs multiError
+	bindStruct(v.Elem(), "", properties, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func bindStruct(structVal reflect.Value, prefix string, properties map[string]interface{}, errs *multiError) {
+	structType := structVal.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		tag, ok := field.Tag.Lookup("config")
+		if !ok {
+			continue
+		}
+		key, defaultValue, hasDefault, required := parseConfigTag(tag)
+
+		fullKey := key
+		if prefix != "" {
+			fullKey = prefix + "." + key
+		}
+
+		fieldVal := structVal.Field(i)
+		if fieldVal.Kind() == reflect.Struct && fieldVal.Type() != durationType {
+			bindStruct(fieldVal, fullKey, properties, errs)
+			continue
+		}
+
+		raw, exists := properties[fullKey]
+		if decErr, failed := raw.(*propertyDecryptError); exists && failed {
+			// A value that failed {cipher} decryption must never be read as
+			// a value (see GetValue); treat it the same as a missing key.
+			exists = false
+			if required {
+				*errs = append(*errs, fmt.Errorf("%s: %w", fullKey, decErr))
+				continue
+			}
+		}
+		if !exists {
+			switch {
+			case hasDefault:
+				raw = defaultValue
+			case required:
+				*errs = append(*errs, fmt.Errorf("%s: required key is missing", fullKey))
+				continue
+			default:
+				continue
+			}
+		}
+
+		if err := setFieldValue(fieldVal, raw); err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", fullKey, err))
+		}
+	}
+}
+
+// parseConfigTag splits a `config:"..."` tag into its key and modifiers.
+func parseConfigTag(tag string) (key, defaultValue string, hasDefault, required bool) {
+	parts := strings.Split(tag, ",")
+	key = parts[0]
+	for _, opt := range parts[1:] {
+		if opt == "required" {
+			required = true
+			continue
+		}
+		if v, ok := strings.CutPrefix(opt, "default="); ok {
+			defaultValue = v
+			hasDefault = true
+		}
+	}
+	return key, defaultValue, hasDefault, required
+}
+
+// setFieldValue converts raw (a property value, or a default-tag string)
+// into field's type and assigns it.
+func setFieldValue(field reflect.Value, raw interface{}) error {
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(fmt.Sprintf("%v", raw))
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", raw, err)
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(fmt.Sprintf("%v", raw))
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toInt64(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", raw, err)
+		}
+		field.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := toInt64(raw)
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q: %w", raw, err)
+		}
+		if n < 0 {
+			return fmt.Errorf("invalid unsigned integer %q: value is negative", raw)
+		}
+		field.SetUint(uint64(n))
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(fmt.Sprintf("%v", raw), 64)
+		if err != nil {
+			return fmt.Errorf("invalid float %q: %w", raw, err)
+		}
+		field.SetFloat(f)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(fmt.Sprintf("%v", raw))
+		if err != nil {
+			return fmt.Errorf("invalid bool %q: %w", raw, err)
+		}
+		field.SetBool(b)
+
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice element type %s", field.Type().Elem())
+		}
+		field.Set(reflect.ValueOf(toStringSlice(raw)))
+
+	case reflect.Map:
+		if field.Type().Key().Kind() != reflect.String || field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type %s", field.Type())
+		}
+		m, err := toStringMap(raw)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(m))
+
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
+
+func toInt64(raw interface{}) (int64, error) {
+	switch v := raw.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	default:
+		return strconv.ParseInt(fmt.Sprintf("%v", raw), 10, 64)
+	}
+}
+
+func toStringSlice(raw interface{}) []string {
+	if items, ok := raw.([]interface{}); ok {
+		result := make([]string, len(items))
+		for i, item := range items {
+			result[i] = fmt.Sprintf("%v", item)
+		}
+		return result
+	}
+
+	parts := strings.Split(fmt.Sprintf("%v", raw), ",")
+	result := make([]string, len(parts))
+	for i, part := range parts {
+		result[i] = strings.TrimSpace(part)
+	}
+	return result
+}
+
+func toStringMap(raw interface{}) (map[string]string, error) {
+	rawMap, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot bind %q to map[string]string", raw)
+	}
+	result := make(map[string]string, len(rawMap))
+	for k, v := range rawMap {
+		result[k] = fmt.Sprintf("%v", v)
+	}
+	return result, nil
+}
+
+// multiError collects every path that failed to bind during Unmarshal.
+type multiError []error
+
+func (e multiError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("gocloudclient: %d binding error(s): %s", len(e), strings.Join(msgs, "; "))
+}
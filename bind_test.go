@@ -0,0 +1,126 @@
+package gocloudclient
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type dataSourceConfig struct {
+	Host string `config:"host"`
+	Port int    `config:"port"`
+}
+
+type appConfig struct {
+	Name       string            `config:"spring.application.name,default=unnamed"`
+	Debug      bool              `config:"app.debug"`
+	Timeout    time.Duration     `config:"app.timeout"`
+	Tags       []string          `config:"app.tags"`
+	Labels     map[string]string `config:"app.labels"`
+	DataSource dataSourceConfig  `config:"spring.datasource"`
+	Required   string            `config:"app.missing,required"`
+}
+
+func newBindableConfig(source map[string]interface{}) *ConfigResponse {
+	return &ConfigResponse{
+		PropertySources: []PropertySource{
+			{Name: "application.yml", Source: source},
+		},
+	}
+}
+
+func TestUnmarshalBindsScalarsAndNested(t *testing.T) {
+	config := newBindableConfig(map[string]interface{}{
+		"app.debug":              true,
+		"app.timeout":            "30s",
+		"app.tags":               "a,b,c",
+		"app.labels":             map[string]interface{}{"env": "prod"},
+		"spring.datasource.host": "db.internal",
+		"spring.datasource.port": 5432,
+		"app.missing":            "present",
+	})
+
+	var target appConfig
+	err := config.Unmarshal(&target)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if target.Name != "unnamed" {
+		t.Errorf("Name = %q, want default %q", target.Name, "unnamed")
+	}
+	if !target.Debug {
+		t.Error("Debug = false, want true")
+	}
+	if target.Timeout != 30*time.Second {
+		t.Errorf("Timeout = %v, want 30s", target.Timeout)
+	}
+	if len(target.Tags) != 3 || target.Tags[0] != "a" || target.Tags[2] != "c" {
+		t.Errorf("Tags = %v, want [a b c]", target.Tags)
+	}
+	if target.Labels["env"] != "prod" {
+		t.Errorf("Labels[env] = %q, want 'prod'", target.Labels["env"])
+	}
+	if target.DataSource.Host != "db.internal" || target.DataSource.Port != 5432 {
+		t.Errorf("DataSource = %+v, want {db.internal 5432}", target.DataSource)
+	}
+}
+
+func TestUnmarshalMissingRequiredKeyReturnsError(t *testing.T) {
+	config := newBindableConfig(map[string]interface{}{})
+
+	var target appConfig
+	err := config.Unmarshal(&target)
+	if err == nil {
+		t.Fatal("Unmarshal() should fail when a required key is missing")
+	}
+	if !strings.Contains(err.Error(), "app.missing") {
+		t.Errorf("error %q should mention the missing key 'app.missing'", err.Error())
+	}
+}
+
+func TestUnmarshalUndecryptableValueIsNotBoundOrExposed(t *testing.T) {
+	config := newBindableConfig(map[string]interface{}{
+		"spring.datasource.host": cipherPrefix + "deadbeef",
+		"spring.datasource.port": 5432,
+		"app.missing":            "present",
+	})
+	config.decryptors = NewDecryptorRegistry()
+
+	var target appConfig
+	err := config.Unmarshal(&target)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v, want nil for a non-required undecryptable field", err)
+	}
+	if target.DataSource.Host != "" {
+		t.Errorf("DataSource.Host = %q, want zero value, not the decrypt failure text", target.DataSource.Host)
+	}
+}
+
+func TestUnmarshalUndecryptableRequiredValueReturnsError(t *testing.T) {
+	config := newBindableConfig(map[string]interface{}{
+		"app.missing": cipherPrefix + "deadbeef",
+	})
+	config.decryptors = NewDecryptorRegistry()
+
+	var target appConfig
+	err := config.Unmarshal(&target)
+	if err == nil {
+		t.Fatal("Unmarshal() should fail when a required key fails to decrypt")
+	}
+	if !strings.Contains(err.Error(), "app.missing") {
+		t.Errorf("error %q should mention the required key 'app.missing'", err.Error())
+	}
+	if strings.Contains(err.Error(), target.Required) && target.Required != "" {
+		t.Errorf("Required field should not have been bound to decrypt failure text, got %q", target.Required)
+	}
+}
+
+func TestUnmarshalRequiresStructPointer(t *testing.T) {
+	config := newBindableConfig(map[string]interface{}{})
+
+	var notAPointer appConfig
+	if err := config.Unmarshal(notAPointer); err == nil {
+		t.Error("Unmarshal() should reject a non-pointer target")
+	}
+}
@@ -1,80 +1,176 @@
 package gocloudclient
 
 import (
+	"context"
 	"fmt"
-	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
-// CachedClient wraps a Client with caching capabilities
+// defaultMaxEntries bounds the cache size when callers don't configure one
+// via WithMaxEntries, so key churn can't grow it unboundedly.
+const defaultMaxEntries = 1000
+
+// CachedClient wraps an AppClient with a bounded, TTL-based LRU cache.
+// Concurrent GetConfig calls for the same application/profile/label
+// collapse into a single upstream request via singleflight.
 type CachedClient struct {
-	client     *Client
-	cache      map[string]*cacheEntry
-	cacheMutex sync.RWMutex
-	defaultTTL time.Duration
+	client      *AppClient
+	cache       *lruCache
+	group       singleflight.Group
+	defaultTTL  time.Duration
+	negativeTTL time.Duration
+	metrics     Metrics
+}
+
+// CachedClientOption configures a CachedClient constructed via
+// NewCachedClientWithOptions.
+type CachedClientOption func(*CachedClient)
+
+// WithMaxEntries bounds the number of cached application/profile/label
+// entries, evicting the least recently used entry once exceeded. 0 (the
+// zero value) means unbounded.
+func WithMaxEntries(maxEntries int) CachedClientOption {
+	return func(c *CachedClient) {
+		c.cache.maxEntries = maxEntries
+	}
+}
+
+// WithNegativeTTL caches a failed fetch's error for ttl, so repeated
+// requests against a down config server fail fast instead of each
+// retrying the full round trip. Disabled (the default) when ttl is 0.
+func WithNegativeTTL(ttl time.Duration) CachedClientOption {
+	return func(c *CachedClient) {
+		c.negativeTTL = ttl
+	}
+}
+
+// WithCacheMetrics wires cache hit/miss/eviction/latency/error events to m.
+func WithCacheMetrics(m Metrics) CachedClientOption {
+	return func(c *CachedClient) {
+		c.metrics = m
+		c.cache.metrics = m
+	}
 }
 
-type cacheEntry struct {
-	config    *ConfigResponse
-	expiresAt time.Time
+// NewCachedClient creates a new cached client wrapper with a default,
+// bounded LRU cache and no negative-result caching. Prefer
+// NewCachedClientWithOptions for more control.
+func NewCachedClient(client *AppClient, defaultTTL time.Duration) *CachedClient {
+	return NewCachedClientWithOptions(client, defaultTTL)
 }
 
-// NewCachedClient creates a new cached client wrapper
-func NewCachedClient(client *Client, defaultTTL time.Duration) *CachedClient {
+// NewCachedClientWithOptions creates a cached client wrapper configured by
+// opts.
+func NewCachedClientWithOptions(client *AppClient, defaultTTL time.Duration, opts ...CachedClientOption) *CachedClient {
 	if defaultTTL == 0 {
 		defaultTTL = 5 * time.Minute // Default 5 minutes
 	}
 
-	return &CachedClient{
+	c := &CachedClient{
 		client:     client,
-		cache:      make(map[string]*cacheEntry),
 		defaultTTL: defaultTTL,
+		metrics:    noopMetrics{},
 	}
+	c.cache = newLRUCache(defaultMaxEntries, c.metrics)
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
-// GetConfig fetches configuration with caching
+// GetConfig fetches configuration with caching. It is equivalent to
+// GetConfigContext with context.Background().
 func (c *CachedClient) GetConfig(application, profile, label string) (*ConfigResponse, error) {
-	cacheKey := fmt.Sprintf("%s:%s:%s", application, profile, label)
-
-	// Try to get from cache
-	c.cacheMutex.RLock()
-	if entry, exists := c.cache[cacheKey]; exists {
-		if time.Now().Before(entry.expiresAt) {
-			config := entry.config
-			c.cacheMutex.RUnlock()
-			return config, nil
-		}
-	}
-	c.cacheMutex.RUnlock()
+	return c.GetConfigContext(context.Background(), application, profile, label)
+}
+
+// GetConfigContext fetches configuration with caching, honoring ctx
+// cancellation and deadlines on a cache miss.
+func (c *CachedClient) GetConfigContext(ctx context.Context, application, profile, label string) (*ConfigResponse, error) {
+	key := cacheKey(application, profile, label)
 
-	// Fetch from server
-	config, err := c.client.GetConfig()
-	if err != nil {
-		return nil, err
+	if entry, exists := c.cache.get(key); exists {
+		c.metrics.OnHit(key)
+		return entry.config, entry.err
 	}
+	c.metrics.OnMiss(key)
+
+	return c.fetchAndCache(ctx, key, application, profile, label)
+}
+
+// Refresh proactively re-populates the cache entry for
+// application/profile/label, collapsing concurrent callers into a single
+// upstream request the same way GetConfig does.
+func (c *CachedClient) Refresh(ctx context.Context, application, profile, label string) (*ConfigResponse, error) {
+	key := cacheKey(application, profile, label)
+	return c.fetchAndCache(ctx, key, application, profile, label)
+}
 
-	// Store in cache
-	c.cacheMutex.Lock()
-	c.cache[cacheKey] = &cacheEntry{
-		config:    config,
-		expiresAt: time.Now().Add(c.defaultTTL),
+// fetchAndCache fetches application/profile/label from the underlying
+// client, deduplicating concurrent calls for the same key via singleflight,
+// and stores the result (including a negative result, if configured) in
+// the cache.
+//
+// The upstream fetch runs with a context detached from any single waiter:
+// singleflight.Group.Do executes the shared work on whichever caller's
+// goroutine happens to trigger it, so threading that caller's ctx through
+// would let its deadline cancel the fetch out from under every other
+// caller sharing the key. Instead each waiter only selects on its own
+// ctx.Done() to stop waiting; the fetch itself keeps running (and still
+// populates the cache) for the others.
+func (c *CachedClient) fetchAndCache(ctx context.Context, key, application, profile, label string) (*ConfigResponse, error) {
+	type fetchResult struct {
+		config *ConfigResponse
+		err    error
 	}
-	c.cacheMutex.Unlock()
+	done := make(chan fetchResult, 1)
 
-	return config, nil
+	go func() {
+		v, err, _ := c.group.Do(key, func() (interface{}, error) {
+			start := time.Now()
+			config, err := c.client.GetConfigContext(context.Background(), application, profile, label)
+			c.metrics.OnFetchLatency(time.Since(start))
+
+			if err != nil {
+				c.metrics.OnError(key, err)
+				if c.negativeTTL > 0 {
+					c.cache.set(key, &cacheEntry{err: err, expiresAt: time.Now().Add(c.negativeTTL)})
+				}
+				return nil, err
+			}
+
+			c.cache.set(key, &cacheEntry{config: config, expiresAt: time.Now().Add(c.defaultTTL)})
+			return config, nil
+		})
+		if err != nil {
+			done <- fetchResult{err: err}
+			return
+		}
+		done <- fetchResult{config: v.(*ConfigResponse)}
+	}()
+
+	select {
+	case result := <-done:
+		return result.config, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 
 // ClearCache clears all cached entries
 func (c *CachedClient) ClearCache() {
-	c.cacheMutex.Lock()
-	defer c.cacheMutex.Unlock()
-	c.cache = make(map[string]*cacheEntry)
+	c.cache.clear()
 }
 
 // InvalidateCache invalidates cache for a specific application/profile/label combination
 func (c *CachedClient) InvalidateCache(application, profile, label string) {
-	cacheKey := fmt.Sprintf("%s:%s:%s", application, profile, label)
-	c.cacheMutex.Lock()
-	defer c.cacheMutex.Unlock()
-	delete(c.cache, cacheKey)
+	c.cache.delete(cacheKey(application, profile, label))
+}
+
+func cacheKey(application, profile, label string) string {
+	return fmt.Sprintf("%s:%s:%s", application, profile, label)
 }
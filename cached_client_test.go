@@ -0,0 +1,198 @@
+package gocloudclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type recordingMetrics struct {
+	mu        sync.Mutex
+	hits      int
+	misses    int
+	evictions int
+	errors    int
+}
+
+func (m *recordingMetrics) OnHit(key string)  { m.mu.Lock(); m.hits++; m.mu.Unlock() }
+func (m *recordingMetrics) OnMiss(key string) { m.mu.Lock(); m.misses++; m.mu.Unlock() }
+func (m *recordingMetrics) OnEviction(key string) {
+	m.mu.Lock()
+	m.evictions++
+	m.mu.Unlock()
+}
+func (m *recordingMetrics) OnFetchLatency(d time.Duration) {}
+func (m *recordingMetrics) OnError(key string, err error) {
+	m.mu.Lock()
+	m.errors++
+	m.mu.Unlock()
+}
+
+func newStubConfigServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ConfigResponse{Name: "myapp"})
+	}))
+}
+
+func TestCachedClientEvictsLeastRecentlyUsed(t *testing.T) {
+	server := newStubConfigServer()
+	defer server.Close()
+
+	client, _ := NewClient(ClientConfig{BaseURL: server.URL})
+	metrics := &recordingMetrics{}
+	cachedClient := NewCachedClientWithOptions(client, time.Hour, WithMaxEntries(2), WithCacheMetrics(metrics))
+
+	cachedClient.GetConfig("app1", "dev", "master")
+	cachedClient.GetConfig("app2", "dev", "master")
+	cachedClient.GetConfig("app3", "dev", "master") // should evict app1
+
+	metrics.mu.Lock()
+	evictions := metrics.evictions
+	metrics.mu.Unlock()
+	if evictions != 1 {
+		t.Fatalf("evictions = %d, want 1", evictions)
+	}
+
+	if _, ok := cachedClient.cache.get(cacheKey("app1", "dev", "master")); ok {
+		t.Error("app1 should have been evicted")
+	}
+	if _, ok := cachedClient.cache.get(cacheKey("app3", "dev", "master")); !ok {
+		t.Error("app3 should still be cached")
+	}
+}
+
+func TestCachedClientSingleflightCollapsesConcurrentMisses(t *testing.T) {
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		time.Sleep(20 * time.Millisecond)
+		json.NewEncoder(w).Encode(ConfigResponse{Name: "myapp"})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(ClientConfig{BaseURL: server.URL})
+	cachedClient := NewCachedClient(client, time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cachedClient.GetConfig("myapp", "dev", "master"); err != nil {
+				t.Errorf("GetConfig() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&fetches); got != 1 {
+		t.Errorf("expected 1 upstream fetch for concurrent misses, got %d", got)
+	}
+}
+
+func TestCachedClientShortTimeoutCallerDoesNotCancelSharedFetch(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		json.NewEncoder(w).Encode(ConfigResponse{Name: "myapp"})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(ClientConfig{BaseURL: server.URL})
+	cachedClient := NewCachedClient(client, time.Hour)
+
+	var shortErr error
+	var longConfig *ConfigResponse
+	var longErr error
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, shortErr = cachedClient.GetConfigContext(ctx, "myapp", "dev", "master")
+	}()
+
+	<-started
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		longCtx, longCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer longCancel()
+		longConfig, longErr = cachedClient.GetConfigContext(longCtx, "myapp", "dev", "master")
+	}()
+
+	// Give the short-timeout caller time to hit its deadline while the
+	// upstream request is still blocked on release.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if shortErr == nil {
+		t.Fatal("short-timeout caller should have returned its own deadline error")
+	}
+	if longErr != nil {
+		t.Fatalf("long-timeout caller should still receive the shared fetch's result, got error: %v", longErr)
+	}
+	if longConfig.Name != "myapp" {
+		t.Errorf("config.Name = %q, want %q", longConfig.Name, "myapp")
+	}
+}
+
+func TestCachedClientNegativeTTLCachesErrors(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(ClientConfig{BaseURL: server.URL})
+	metrics := &recordingMetrics{}
+	cachedClient := NewCachedClientWithOptions(client, time.Hour, WithNegativeTTL(time.Hour), WithCacheMetrics(metrics))
+
+	if _, err := cachedClient.GetConfig("myapp", "dev", "master"); err == nil {
+		t.Fatal("GetConfig() should return the upstream error")
+	}
+	if _, err := cachedClient.GetConfig("myapp", "dev", "master"); err == nil {
+		t.Fatal("second GetConfig() should replay the cached error")
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("expected 1 upstream request with negative caching enabled, got %d", got)
+	}
+	metrics.mu.Lock()
+	errs := metrics.errors
+	metrics.mu.Unlock()
+	if errs != 1 {
+		t.Errorf("OnError should have fired once, fired %d times", errs)
+	}
+}
+
+func TestCachedClientRefreshRepopulatesEntry(t *testing.T) {
+	server := newStubConfigServer()
+	defer server.Close()
+
+	client, _ := NewClient(ClientConfig{BaseURL: server.URL})
+	cachedClient := NewCachedClient(client, time.Hour)
+
+	if _, err := cachedClient.GetConfig("myapp", "dev", "master"); err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if _, err := cachedClient.Refresh(context.Background(), "myapp", "dev", "master"); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if _, ok := cachedClient.cache.get(cacheKey("myapp", "dev", "master")); !ok {
+		t.Error("Refresh() should leave the entry cached")
+	}
+}
@@ -0,0 +1,169 @@
+package gocloudclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ClientOption configures an AppClient constructed via NewClientWithOptions.
+type ClientOption func(*AppClient)
+
+// WithHTTPClient overrides the *http.Client used to execute requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *AppClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL sets the Spring Cloud Config Server base URL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *AppClient) {
+		c.BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithBasicAuth sets static basic auth credentials.
+func WithBasicAuth(username, password string) ClientOption {
+	return func(c *AppClient) {
+		c.Username = username
+		c.Password = password
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *AppClient) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithBearerTokenSource sets a function invoked before each request to
+// obtain a bearer token, for OAuth2/Vault-style token refresh. When set, it
+// takes precedence over basic auth.
+func WithBearerTokenSource(tokenSource func(ctx context.Context) (string, error)) ClientOption {
+	return func(c *AppClient) {
+		c.tokenSource = tokenSource
+	}
+}
+
+// WithRetryPolicy enables retrying idempotent GET requests up to maxAttempts
+// times (including the initial attempt) on 5xx responses and network
+// errors, waiting according to backoff between attempts.
+func WithRetryPolicy(maxAttempts int, backoff BackoffStrategy) ClientOption {
+	return func(c *AppClient) {
+		c.retry = &retryPolicy{maxAttempts: maxAttempts, backoff: backoff}
+	}
+}
+
+// retryPolicy controls how AppClient retries failed GET requests.
+type retryPolicy struct {
+	maxAttempts int
+	backoff     BackoffStrategy
+}
+
+// NewClientWithOptions creates an AppClient from functional options. BaseURL
+// must be set via WithBaseURL.
+func NewClientWithOptions(opts ...ClientOption) (*AppClient, error) {
+	c := &AppClient{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.BaseURL == "" {
+		return nil, fmt.Errorf("baseURL is required")
+	}
+
+	return c, nil
+}
+
+// authenticate applies the client's User-Agent and credentials to req,
+// preferring a bearer token source over static basic auth when both are
+// configured.
+func (c *AppClient) authenticate(ctx context.Context, req *http.Request) error {
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	if c.tokenSource != nil {
+		token, err := c.tokenSource(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to obtain bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+
+	if c.Username != "" && c.Password != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	return nil
+}
+
+// doWithRetry executes the request returned by buildReq, retrying on 5xx
+// responses and network errors according to the client's retry policy.
+// buildReq is called once per attempt so it must return a fresh, unsent
+// request bound to ctx. Retries honor ctx.Done() while waiting out the
+// backoff delay. On success the caller owns the returned response body.
+func (c *AppClient) doWithRetry(ctx context.Context, buildReq func() (*http.Request, error)) (*http.Response, error) {
+	policy := c.retry
+	if policy == nil {
+		policy = &retryPolicy{maxAttempts: 1}
+	}
+	maxAttempts := policy.maxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			if policy.backoff == nil {
+				// No retry is made without a backoff strategy; report
+				// only the attempts actually made.
+				maxAttempts = attempt
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(policy.backoff.Backoff(attempt - 1)):
+			}
+		}
+
+		req, err := buildReq()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.authenticate(ctx, req); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to execute request: %w", err)
+			continue
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		statusErr := fmt.Errorf("config server returned status %d: %s", resp.StatusCode, string(body))
+		if resp.StatusCode >= http.StatusInternalServerError {
+			lastErr = statusErr
+			continue
+		}
+		return nil, statusErr
+	}
+
+	return nil, fmt.Errorf("request failed after %d attempt(s): %w", maxAttempts, lastErr)
+}
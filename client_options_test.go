@@ -0,0 +1,112 @@
+package gocloudclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetConfigContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetConfigContext(ctx, "myapp", "dev", "master"); err == nil {
+		t.Error("GetConfigContext() should fail when the context deadline is exceeded")
+	}
+}
+
+func TestGetConfigRetriesOn5xx(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"myapp"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(
+		WithBaseURL(server.URL),
+		WithRetryPolicy(3, NewExponentialBackoff(time.Millisecond, 5*time.Millisecond)),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	config, err := client.GetConfig("myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if config.Name != "myapp" {
+		t.Errorf("config.Name = %q, want %q", config.Name, "myapp")
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestGetConfigDoesNotRetryOn4xx(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(
+		WithBaseURL(server.URL),
+		WithRetryPolicy(3, NewExponentialBackoff(time.Millisecond, 5*time.Millisecond)),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.GetConfig("myapp", "dev", "master"); err == nil {
+		t.Fatal("GetConfig() should return an error for a 404 response")
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt for a non-retryable status, got %d", attempts)
+	}
+}
+
+func TestWithBearerTokenSource(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"myapp"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(
+		WithBaseURL(server.URL),
+		WithBearerTokenSource(func(ctx context.Context) (string, error) {
+			return "test-token", nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.GetConfig("myapp", "dev", "master"); err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if gotAuth != "Bearer test-token" {
+		t.Errorf("Authorization header = %q, want %q", gotAuth, "Bearer test-token")
+	}
+}
@@ -1,6 +1,8 @@
 package gocloudclient
 
 import (
+	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,25 +14,30 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// cipherPrefix marks a property value as encrypted, matching Spring Cloud
+// Config Server's convention for values sourced from an encrypted backend.
+const cipherPrefix = "{cipher}"
+
 // AppClient represents a Spring Cloud Config Server client
 type AppClient struct {
-	BaseURL         string `yaml:"base_url"`
-	Timeout         int    `yaml:"timeout"`
-	httpClient      *http.Client
-	ApplicationName string `yaml:"application_name"`
-	Profile         string `yaml:"profile"`
-	Username        string `yaml:"username"`
-	Password        string `yaml:"password"`
+	BaseURL      string
+	Username     string
+	Password     string
+	httpClient   *http.Client
+	userAgent    string
+	tokenSource  func(ctx context.Context) (string, error)
+	retry        *retryPolicy
+	decryptors   *DecryptorRegistry
+	fallbackPath string
 }
 
-type AppClientConfig struct {
-	BaseURL         string `yaml:"base_url"`
-	Timeout         int    `yaml:"timeout"`
-	httpClient      *http.Client
-	ApplicationName string `yaml:"application_name"`
-	Profile         string `yaml:"profile"`
-	Username        string `yaml:"username"`
-	Password        string `yaml:"password"`
+// WithFallback enables local snapshot fallback: every successful
+// GetConfig/GetConfigContext call persists its result to path, and if a
+// later fetch fails (e.g. the config server is temporarily unreachable),
+// the last known-good snapshot is served instead with ConfigResponse.Stale
+// set to true.
+func (c *AppClient) WithFallback(path string) {
+	c.fallbackPath = path
 }
 
 // ConfigResponse represents the response from Spring Cloud Config Server
@@ -41,9 +48,16 @@ type ConfigResponse struct {
 	Version         string           `json:"version,omitempty"`
 	State           string           `json:"state,omitempty"`
 	PropertySources []PropertySource `json:"propertySources"`
-}
 
-type ConfigEnvVariables struct {
+	// Stale is true when this response was served from a local fallback
+	// snapshot (see AppClient.WithFallback) because the config server was
+	// unreachable.
+	Stale bool `json:"-"`
+
+	// decryptors resolves {cipher}-prefixed values fetched by the AppClient
+	// that produced this response. Nil when the response was built without
+	// one, in which case encrypted values cannot be decrypted.
+	decryptors *DecryptorRegistry
 }
 
 // PropertySource represents a property source in the config response
@@ -61,99 +75,127 @@ type ClientConfig struct {
 	HTTPClient *http.Client
 }
 
-// NewClient creates a new Spring Cloud Config Server client
-func NewClient() (*AppClient, error) {
-	dir, _ := os.Getwd()
-	data, err := os.ReadFile(dir + "/app.yaml")
-	if err != nil {
-		return nil, err
-	}
-	config := &AppClient{}
-	if err := yaml.Unmarshal(data, config); err != nil {
-		return nil, err
-	}
+// NewClient creates a new Spring Cloud Config Server client. It is preserved
+// for backward compatibility; new code should prefer NewClientWithOptions.
+func NewClient(config ClientConfig) (*AppClient, error) {
+	opts := []ClientOption{WithBaseURL(config.BaseURL)}
 
-	fmt.Printf("Config: %v\n", config)
+	if config.HTTPClient != nil {
+		opts = append(opts, WithHTTPClient(config.HTTPClient))
+	} else if config.Timeout > 0 {
+		opts = append(opts, WithHTTPClient(&http.Client{Timeout: time.Duration(config.Timeout) * time.Second}))
+	}
 
-	if config.BaseURL == "" {
-		return nil, fmt.Errorf("baseURL is required")
+	if config.Username != "" || config.Password != "" {
+		opts = append(opts, WithBasicAuth(config.Username, config.Password))
 	}
 
-	// Remove trailing slash if present
-	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	return NewClientWithOptions(opts...)
+}
 
-	timeout := time.Duration(config.Timeout) * time.Second
-	if timeout == 0 {
-		timeout = 30 * time.Second
-	}
-	config.httpClient = &http.Client{
-		Timeout: timeout,
+// RegisterDecryptor associates alias with a Decryptor used to resolve
+// {cipher}{key:alias}... property values fetched by this client. Pass ""
+// as the alias to register the default decryptor for values with no
+// {key:...} prefix.
+func (c *AppClient) RegisterDecryptor(alias string, d Decryptor) {
+	if c.decryptors == nil {
+		c.decryptors = NewDecryptorRegistry()
 	}
-	return config, nil
+	c.decryptors.Register(alias, d)
 }
 
-// GetConfig fetches configuration from Spring Cloud Config Server
+// GetConfig fetches configuration from Spring Cloud Config Server. It is
+// equivalent to GetConfigContext with context.Background().
 // Parameters:
 //   - application: The application name (e.g., "myapp")
 //   - profile: The profile (e.g., "dev", "prod"). Can be comma-separated for multiple profiles
 //   - label: Optional label/branch (e.g., "master", "develop"). Defaults to "master" if empty
-func (c *AppClient) GetConfig() (*ConfigResponse, error) {
-	if c.ApplicationName == "" {
-		return nil, fmt.Errorf("application name is required")
-	}
+func (c *AppClient) GetConfig(application, profile, label string) (*ConfigResponse, error) {
+	return c.GetConfigContext(context.Background(), application, profile, label)
+}
 
-	if c.Profile == "" {
-		c.ApplicationName = "default"
+// GetConfigContext fetches configuration from Spring Cloud Config Server,
+// honoring ctx cancellation and deadlines and retrying according to the
+// client's retry policy (see WithRetryPolicy).
+func (c *AppClient) GetConfigContext(ctx context.Context, application, profile, label string) (*ConfigResponse, error) {
+	if application == "" {
+		return nil, fmt.Errorf("application name is required")
 	}
 
-	// Build the URL: {baseURL}/{application}/{profile}/{label}
-	url := fmt.Sprintf("%s/%s/%s", c.BaseURL, c.ApplicationName, c.Profile)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	if profile == "" {
+		profile = "default"
 	}
 
-	// Set basic auth if credentials are provided
-	if c.Username != "" && c.Password != "" {
-		req.SetBasicAuth(c.Username, c.Password)
+	if label == "" {
+		label = "master"
 	}
 
-	fmt.Println("Debug-2")
-
-	// Set Accept header to prefer JSON
-	req.Header.Set("Accept", "application/json")
-
-	fmt.Printf("Timeout: %v\n", c.httpClient.Timeout)
+	// Build the URL: {baseURL}/{application}/{profile}/{label}
+	url := fmt.Sprintf("%s/%s/%s/%s", c.BaseURL, application, profile, label)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		if snapshot, ok := c.fallbackSnapshot(err); ok {
+			return snapshot, nil
+		}
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("config server returned status %d: %s", resp.StatusCode, string(body))
-	}
-	fmt.Println("Debug-02")
-
 	var configResp ConfigResponse
 	if err := json.NewDecoder(resp.Body).Decode(&configResp); err != nil {
-		fmt.Println("Debug-03")
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
-	fmt.Println("Debug-04")
+	configResp.decryptors = c.decryptors
+
+	if c.fallbackPath != "" {
+		if err := persistSnapshot(c.fallbackPath, &configResp); err != nil {
+			fmt.Fprintf(os.Stderr, "gocloudclient: warning: failed to persist config snapshot to %s: %v\n", c.fallbackPath, err)
+		}
+	}
 
 	return &configResp, nil
 }
 
-func (c *AppClient) Sample() error {
-	return nil
+// fallbackSnapshot loads the last known-good snapshot from c.fallbackPath
+// when fallback is enabled, logging a warning and marking it Stale. ok is
+// false when fallback is disabled or no snapshot could be loaded, in which
+// case fetchErr should be returned to the caller unchanged.
+func (c *AppClient) fallbackSnapshot(fetchErr error) (snapshot *ConfigResponse, ok bool) {
+	if c.fallbackPath == "" {
+		return nil, false
+	}
+
+	snapshot, err := loadSnapshot(c.fallbackPath)
+	if err != nil {
+		return nil, false
+	}
+
+	fmt.Fprintf(os.Stderr, "gocloudclient: warning: config server unreachable (%v), serving last-known-good snapshot from %s\n", fetchErr, c.fallbackPath)
+	snapshot.Stale = true
+	snapshot.decryptors = c.decryptors
+	return snapshot, true
 }
 
-// GetConfigYAML fetches configuration and returns it in YAML format
+// GetConfigYAML fetches configuration and returns it in YAML format. It is
+// equivalent to GetConfigYAMLContext with context.Background().
 func (c *AppClient) GetConfigYAML(application, profile, label string) (string, error) {
+	return c.GetConfigYAMLContext(context.Background(), application, profile, label)
+}
+
+// GetConfigYAMLContext fetches configuration in YAML format, honoring ctx
+// cancellation and deadlines and retrying according to the client's retry
+// policy (see WithRetryPolicy). If the fetch fails and WithFallback is
+// configured, the last known-good snapshot is served instead, re-encoded
+// as YAML, the same way GetConfigContext falls back for JSON callers.
+func (c *AppClient) GetConfigYAMLContext(ctx context.Context, application, profile, label string) (string, error) {
 	if application == "" {
 		return "", fmt.Errorf("application name is required")
 	}
@@ -171,28 +213,22 @@ func (c *AppClient) GetConfigYAML(application, profile, label string) (string, e
 		url = fmt.Sprintf("%s/%s/%s-%s.yml", c.BaseURL, label, application, profile)
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	if c.Username != "" && c.Password != "" {
-		req.SetBasicAuth(c.Username, c.Password)
-	}
-
-	req.Header.Set("Accept", "application/x-yaml, text/yaml, text/x-yaml")
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Accept", "application/x-yaml, text/yaml, text/x-yaml")
+		return req, nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
+		if snapshot, ok := c.fallbackSnapshot(err); ok {
+			return snapshot.ToYAML()
+		}
+		return "", err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("config server returned status %d: %s", resp.StatusCode, string(body))
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", fmt.Errorf("failed to read response body: %w", err)
@@ -202,26 +238,97 @@ func (c *AppClient) GetConfigYAML(application, profile, label string) (string, e
 }
 
 // GetPropertySources returns all property sources flattened into a single map
-// Later property sources (in the array) override earlier ones
+// Later property sources (in the array) override earlier ones. Values with a
+// {cipher} prefix are transparently decrypted; a value that fails to decrypt
+// is replaced with an internal error marker rather than the raw ciphertext,
+// so it reads as absent to GetValue/GetString/GetInt/GetBool and as an error
+// to ToYAML/ToJSON.
 func (configResp *ConfigResponse) GetPropertySources() map[string]interface{} {
 	result := make(map[string]interface{})
 
-	// Iterate in reverse order so later sources override earlier ones
-	for i := len(configResp.PropertySources) - 1; i >= 0; i-- {
-		ps := configResp.PropertySources[i]
+	// Iterate forward so later sources override earlier ones
+	for _, ps := range configResp.PropertySources {
 		for k, v := range ps.Source {
 			result[k] = v
 		}
 	}
 
+	for k, v := range result {
+		str, ok := v.(string)
+		if !ok || !strings.HasPrefix(str, cipherPrefix) {
+			continue
+		}
+		plain, err := configResp.decryptProperty(str)
+		if err != nil {
+			result[k] = &propertyDecryptError{key: k, err: err}
+			continue
+		}
+		result[k] = plain
+	}
+
 	return result
 }
 
-// GetValue retrieves a configuration value by key
+// decryptProperty decrypts a single {cipher}[{key:alias}]<ciphertext> value
+// using the Decryptor registered under the parsed alias (the default
+// decryptor if no {key:...} prefix is present).
+func (configResp *ConfigResponse) decryptProperty(raw string) (string, error) {
+	rest := strings.TrimPrefix(raw, cipherPrefix)
+
+	alias := ""
+	if strings.HasPrefix(rest, "{key:") {
+		end := strings.Index(rest, "}")
+		if end < 0 {
+			return "", fmt.Errorf("malformed {key:...} prefix in encrypted value")
+		}
+		alias = rest[len("{key:"):end]
+		rest = rest[end+1:]
+	}
+
+	if configResp.decryptors == nil {
+		return "", fmt.Errorf("no decryptor registered for key %q", alias)
+	}
+	decryptor, ok := configResp.decryptors.Get(alias)
+	if !ok {
+		return "", fmt.Errorf("no decryptor registered for key %q", alias)
+	}
+
+	ciphertext, err := hex.DecodeString(rest)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode ciphertext: %w", err)
+	}
+
+	plain, err := decryptor.Decrypt(alias, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("decryption failed: %w", err)
+	}
+
+	return string(plain), nil
+}
+
+// propertyDecryptError is stored in a flattened property map in place of a
+// value whose {cipher} prefix could not be decrypted.
+type propertyDecryptError struct {
+	key string
+	err error
+}
+
+func (e *propertyDecryptError) Error() string {
+	return fmt.Sprintf("failed to decrypt property %q: %v", e.key, e.err)
+}
+
+// GetValue retrieves a configuration value by key. A value that failed
+// {cipher} decryption is reported as absent rather than returning ciphertext.
 func (configResp *ConfigResponse) GetValue(key string) (interface{}, bool) {
 	properties := configResp.GetPropertySources()
 	value, exists := properties[key]
-	return value, exists
+	if !exists {
+		return nil, false
+	}
+	if _, failed := value.(*propertyDecryptError); failed {
+		return nil, false
+	}
+	return value, true
 }
 
 // GetString retrieves a configuration value as a string
@@ -279,6 +386,9 @@ func (configResp *ConfigResponse) GetBool(key string) (bool, bool) {
 // ToYAML converts the configuration response to YAML format
 func (configResp *ConfigResponse) ToYAML() (string, error) {
 	properties := configResp.GetPropertySources()
+	if err := firstDecryptError(properties); err != nil {
+		return "", err
+	}
 	data, err := yaml.Marshal(properties)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal to YAML: %w", err)
@@ -289,9 +399,24 @@ func (configResp *ConfigResponse) ToYAML() (string, error) {
 // ToJSON converts the configuration response to JSON format
 func (configResp *ConfigResponse) ToJSON() (string, error) {
 	properties := configResp.GetPropertySources()
+	if err := firstDecryptError(properties); err != nil {
+		return "", err
+	}
 	data, err := json.MarshalIndent(properties, "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal to JSON: %w", err)
 	}
 	return string(data), nil
 }
+
+// firstDecryptError returns the first propertyDecryptError found in
+// properties, if any, so callers that can return an error do not silently
+// serialize a decryption failure marker.
+func firstDecryptError(properties map[string]interface{}) error {
+	for _, v := range properties {
+		if decErr, failed := v.(*propertyDecryptError); failed {
+			return decErr
+		}
+	}
+	return nil
+}
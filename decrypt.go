@@ -0,0 +1,155 @@
+package gocloudclient
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Decryptor decrypts a single {cipher}-prefixed property value. alias is the
+// optional key alias parsed from a {key:alias} prefix, or "" when the value
+// carries no alias.
+type Decryptor interface {
+	Decrypt(alias string, ciphertext []byte) ([]byte, error)
+}
+
+// DecryptorRegistry maps a key alias (as emitted in Spring's {key:alias}
+// prefix) to the Decryptor that knows how to unwrap values encrypted under
+// that key. The empty alias "" is used for values with no {key:...} prefix.
+// Safe for concurrent use: an AppClient may have its decryptors registered
+// from one goroutine while a Watch or CachedClient reads config on others.
+type DecryptorRegistry struct {
+	mu         sync.RWMutex
+	decryptors map[string]Decryptor
+}
+
+// NewDecryptorRegistry creates an empty decryptor registry.
+func NewDecryptorRegistry() *DecryptorRegistry {
+	return &DecryptorRegistry{decryptors: make(map[string]Decryptor)}
+}
+
+// Register associates alias with d. Passing "" registers the default
+// decryptor used for values without a {key:alias} prefix.
+func (r *DecryptorRegistry) Register(alias string, d Decryptor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decryptors[alias] = d
+}
+
+// Get returns the decryptor registered for alias, if any.
+func (r *DecryptorRegistry) Get(alias string) (Decryptor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.decryptors[alias]
+	return d, ok
+}
+
+// AESDecryptor decrypts values produced by Spring's default symmetric
+// TextEncryptor: a random 16-byte IV prepended to AES/CBC/PKCS7 ciphertext,
+// with the key derived from a passphrase via PBKDF2-HMAC-SHA1.
+type AESDecryptor struct {
+	passphrase string
+	salt       []byte
+	iterations int
+	keyLen     int
+}
+
+// NewAESDecryptor creates a decryptor for values encrypted with passphrase
+// and the given salt, matching Spring's 1024-iteration, PBKDF2-HMAC-SHA1
+// key derivation. salt is used as raw bytes; a hex-encoded salt string is
+// not decoded here.
+func NewAESDecryptor(passphrase string, salt []byte) *AESDecryptor {
+	return &AESDecryptor{
+		passphrase: passphrase,
+		salt:       salt,
+		iterations: 1024,
+		keyLen:     32,
+	}
+}
+
+// Decrypt implements Decryptor.
+func (d *AESDecryptor) Decrypt(alias string, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aes.BlockSize {
+		return nil, fmt.Errorf("gocloudclient: AES ciphertext shorter than one block")
+	}
+
+	iv := ciphertext[:aes.BlockSize]
+	data := ciphertext[aes.BlockSize:]
+	if len(data)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("gocloudclient: AES ciphertext is not a multiple of the block size")
+	}
+
+	key := pbkdf2.Key([]byte(d.passphrase), d.salt, d.iterations, d.keyLen, sha1.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("gocloudclient: failed to create AES cipher: %w", err)
+	}
+
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+
+	return pkcs7Unpad(plain, aes.BlockSize)
+}
+
+func pkcs7Unpad(data []byte, blockSize int) ([]byte, error) {
+	if len(data) == 0 || len(data)%blockSize != 0 {
+		return nil, fmt.Errorf("gocloudclient: invalid PKCS7 padding")
+	}
+	padLen := int(data[len(data)-1])
+	if padLen == 0 || padLen > blockSize || padLen > len(data) {
+		return nil, fmt.Errorf("gocloudclient: invalid PKCS7 padding")
+	}
+	for _, b := range data[len(data)-padLen:] {
+		if int(b) != padLen {
+			return nil, fmt.Errorf("gocloudclient: invalid PKCS7 padding")
+		}
+	}
+	return data[:len(data)-padLen], nil
+}
+
+// RSADecryptor decrypts values produced with an RSA-OAEP public key,
+// using the matching PEM-encoded private key.
+type RSADecryptor struct {
+	key *rsa.PrivateKey
+}
+
+// NewRSADecryptorFromPEM parses a PKCS1 or PKCS8 PEM-encoded RSA private
+// key for use as a Decryptor.
+func NewRSADecryptorFromPEM(pemBytes []byte) (*RSADecryptor, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("gocloudclient: no PEM block found in RSA private key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return &RSADecryptor{key: key}, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("gocloudclient: failed to parse RSA private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("gocloudclient: PEM block does not contain an RSA private key")
+	}
+	return &RSADecryptor{key: key}, nil
+}
+
+// Decrypt implements Decryptor.
+func (d *RSADecryptor) Decrypt(alias string, ciphertext []byte) ([]byte, error) {
+	plain, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, d.key, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gocloudclient: RSA-OAEP decryption failed: %w", err)
+	}
+	return plain, nil
+}
@@ -0,0 +1,176 @@
+package gocloudclient
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+func encryptAES(t *testing.T, passphrase string, salt []byte, plaintext string) string {
+	t.Helper()
+
+	key := pbkdf2.Key([]byte(passphrase), salt, 1024, 32, sha1.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatalf("failed to create AES cipher: %v", err)
+	}
+
+	padded := pkcs7Pad([]byte(plaintext), aes.BlockSize)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		t.Fatalf("failed to generate IV: %v", err)
+	}
+
+	ciphertext := make([]byte, len(padded))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, padded)
+
+	return hex.EncodeToString(append(iv, ciphertext...))
+}
+
+func pkcs7Pad(data []byte, blockSize int) []byte {
+	padLen := blockSize - len(data)%blockSize
+	padding := make([]byte, padLen)
+	for i := range padding {
+		padding[i] = byte(padLen)
+	}
+	return append(data, padding...)
+}
+
+func TestAESDecryptorRoundTrip(t *testing.T) {
+	salt, _ := hex.DecodeString("deadbeef")
+	decryptor := NewAESDecryptor("s3cr3t", salt)
+
+	hexCiphertext := encryptAES(t, "s3cr3t", salt, "hunter2")
+	ciphertext, _ := hex.DecodeString(hexCiphertext)
+
+	plain, err := decryptor.Decrypt("", ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if string(plain) != "hunter2" {
+		t.Errorf("Decrypt() = %q, want %q", plain, "hunter2")
+	}
+}
+
+func TestAESDecryptorWrongPassphrase(t *testing.T) {
+	salt, _ := hex.DecodeString("deadbeef")
+	hexCiphertext := encryptAES(t, "s3cr3t", salt, "hunter2")
+	ciphertext, _ := hex.DecodeString(hexCiphertext)
+
+	decryptor := NewAESDecryptor("wrong-passphrase", salt)
+	if _, err := decryptor.Decrypt("", ciphertext); err == nil {
+		t.Error("Decrypt() with wrong passphrase should fail")
+	}
+}
+
+func generateRSAKeyPEM(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	der := x509.MarshalPKCS1PrivateKey(key)
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: der}
+	return key, pem.EncodeToMemory(block)
+}
+
+func TestRSADecryptorRoundTrip(t *testing.T) {
+	key, keyPEM := generateRSAKeyPEM(t)
+
+	ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &key.PublicKey, []byte("hunter2"), nil)
+	if err != nil {
+		t.Fatalf("failed to encrypt test value: %v", err)
+	}
+
+	decryptor, err := NewRSADecryptorFromPEM(keyPEM)
+	if err != nil {
+		t.Fatalf("NewRSADecryptorFromPEM() error = %v", err)
+	}
+
+	plain, err := decryptor.Decrypt("", ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if string(plain) != "hunter2" {
+		t.Errorf("Decrypt() = %q, want %q", plain, "hunter2")
+	}
+}
+
+func TestGetPropertySourcesDecryptsCipherValues(t *testing.T) {
+	salt, _ := hex.DecodeString("deadbeef")
+	hexCiphertext := encryptAES(t, "s3cr3t", salt, "hunter2")
+
+	registry := NewDecryptorRegistry()
+	registry.Register("", NewAESDecryptor("s3cr3t", salt))
+
+	config := &ConfigResponse{
+		PropertySources: []PropertySource{
+			{
+				Name: "application.yml",
+				Source: map[string]interface{}{
+					"spring.datasource.password": cipherPrefix + hexCiphertext,
+					"app.debug":                  true,
+				},
+			},
+		},
+		decryptors: registry,
+	}
+
+	password, exists := config.GetString("spring.datasource.password")
+	if !exists || password != "hunter2" {
+		t.Errorf("GetString() = %v, %v, want 'hunter2', true", password, exists)
+	}
+}
+
+func TestGetPropertySourcesUndecryptableValueIsNotExposed(t *testing.T) {
+	config := &ConfigResponse{
+		PropertySources: []PropertySource{
+			{
+				Name: "application.yml",
+				Source: map[string]interface{}{
+					"spring.datasource.password": cipherPrefix + "deadbeef",
+				},
+			},
+		},
+	}
+
+	if _, exists := config.GetString("spring.datasource.password"); exists {
+		t.Error("GetString() should report a value that failed decryption as absent")
+	}
+
+	if _, err := config.ToYAML(); err == nil {
+		t.Error("ToYAML() should return an error for a value that failed decryption")
+	}
+}
+
+func TestDecryptorRegistryConcurrentAccess(t *testing.T) {
+	registry := NewDecryptorRegistry()
+	decryptor := NewAESDecryptor("s3cr3t", []byte("0123456789abcdef"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			registry.Register(fmt.Sprintf("alias-%d", i), decryptor)
+		}(i)
+		go func() {
+			defer wg.Done()
+			registry.Get("")
+		}()
+	}
+	wg.Wait()
+}
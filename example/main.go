@@ -14,7 +14,7 @@ func main() {
 		BaseURL:  "http://localhost:8888",
 		Username: "", // Optional: if your config server requires auth
 		Password: "", // Optional
-		Timeout:  30 * time.Second,
+		Timeout:  30,
 	})
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
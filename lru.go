@@ -0,0 +1,113 @@
+package gocloudclient
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// cacheEntry is a single LRU entry. A non-nil err represents a cached
+// negative result (see CachedClient's negativeTTL).
+type cacheEntry struct {
+	config    *ConfigResponse
+	err       error
+	expiresAt time.Time
+}
+
+// lruCache is a size-bounded, TTL-aware cache. A maxEntries of 0 means
+// unbounded. It is safe for concurrent use.
+type lruCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	metrics    Metrics
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type lruListEntry struct {
+	key   string
+	entry *cacheEntry
+}
+
+func newLRUCache(maxEntries int, metrics Metrics) *lruCache {
+	return &lruCache{
+		maxEntries: maxEntries,
+		metrics:    metrics,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// get returns the live entry for key, evicting it first if its TTL has
+// elapsed.
+func (c *lruCache) get(key string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	listEntry := elem.Value.(*lruListEntry)
+	if time.Now().After(listEntry.entry.expiresAt) {
+		c.removeElement(elem)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	return listEntry.entry, true
+}
+
+// set stores entry under key, evicting the least recently used entry if
+// this insertion pushes the cache past maxEntries.
+func (c *lruCache) set(key string, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*lruListEntry).entry = entry
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	elem := c.ll.PushFront(&lruListEntry{key: key, entry: entry})
+	c.items[key] = elem
+
+	if c.maxEntries > 0 && c.ll.Len() > c.maxEntries {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			key := oldest.Value.(*lruListEntry).key
+			c.removeElement(oldest)
+			if c.metrics != nil {
+				c.metrics.OnEviction(key)
+			}
+		}
+	}
+}
+
+// delete removes key from the cache, if present.
+func (c *lruCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.removeElement(elem)
+	}
+}
+
+// clear empties the cache.
+func (c *lruCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll = list.New()
+	c.items = make(map[string]*list.Element)
+}
+
+// removeElement removes elem from both the list and the index. Callers
+// must hold c.mu.
+func (c *lruCache) removeElement(elem *list.Element) {
+	c.ll.Remove(elem)
+	delete(c.items, elem.Value.(*lruListEntry).key)
+}
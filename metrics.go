@@ -0,0 +1,22 @@
+package gocloudclient
+
+import "time"
+
+// Metrics receives CachedClient cache events so callers can wire them to
+// Prometheus, OpenTelemetry, or any other observability backend.
+type Metrics interface {
+	OnHit(key string)
+	OnMiss(key string)
+	OnEviction(key string)
+	OnFetchLatency(d time.Duration)
+	OnError(key string, err error)
+}
+
+// noopMetrics is the default Metrics implementation: it does nothing.
+type noopMetrics struct{}
+
+func (noopMetrics) OnHit(key string)               {}
+func (noopMetrics) OnMiss(key string)              {}
+func (noopMetrics) OnEviction(key string)          {}
+func (noopMetrics) OnFetchLatency(d time.Duration) {}
+func (noopMetrics) OnError(key string, err error)  {}
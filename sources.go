@@ -0,0 +1,195 @@
+package gocloudclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigSource fetches configuration for an application/profile/label from
+// some backing store.
+type ConfigSource interface {
+	Fetch(ctx context.Context, application, profile, label string) (*ConfigResponse, error)
+}
+
+// RemoteSource fetches configuration from a Spring Cloud Config Server via
+// an AppClient.
+type RemoteSource struct {
+	Client *AppClient
+}
+
+// NewRemoteSource wraps client as a ConfigSource.
+func NewRemoteSource(client *AppClient) *RemoteSource {
+	return &RemoteSource{Client: client}
+}
+
+// Fetch implements ConfigSource.
+func (s *RemoteSource) Fetch(ctx context.Context, application, profile, label string) (*ConfigResponse, error) {
+	return s.Client.GetConfigContext(ctx, application, profile, label)
+}
+
+// FileSource reads a local YAML or JSON configuration snapshot, keyed by
+// Path's extension (.json for JSON, anything else for YAML).
+type FileSource struct {
+	Path string
+}
+
+// NewFileSource reads configuration from the snapshot file at path.
+func NewFileSource(path string) *FileSource {
+	return &FileSource{Path: path}
+}
+
+// Fetch implements ConfigSource. It ignores application, profile, and label
+// since a file snapshot carries a single, already-resolved configuration.
+func (s *FileSource) Fetch(ctx context.Context, application, profile, label string) (*ConfigResponse, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config snapshot %q: %w", s.Path, err)
+	}
+	return decodeSnapshot(s.Path, data)
+}
+
+// EnvSource builds configuration from process environment variables using
+// Spring Boot's relaxed binding convention, e.g. SPRING_DATASOURCE_HOST
+// becomes spring.datasource.host.
+type EnvSource struct{}
+
+// NewEnvSource creates an EnvSource.
+func NewEnvSource() *EnvSource {
+	return &EnvSource{}
+}
+
+// Fetch implements ConfigSource. It ignores application, profile, and label
+// since the environment is global to the process.
+func (s *EnvSource) Fetch(ctx context.Context, application, profile, label string) (*ConfigResponse, error) {
+	source := make(map[string]interface{})
+	for _, kv := range os.Environ() {
+		name, value, found := strings.Cut(kv, "=")
+		if !found {
+			continue
+		}
+		source[envKeyToPropertyKey(name)] = value
+	}
+
+	return &ConfigResponse{
+		Name:     application,
+		Profiles: []string{profile},
+		Label:    label,
+		PropertySources: []PropertySource{
+			{Name: "env", Source: source},
+		},
+	}, nil
+}
+
+// envKeyToPropertyKey translates an environment variable name to the
+// dotted, lowercase property key Spring's relaxed binding expects it as,
+// e.g. SPRING_DATASOURCE_HOST -> spring.datasource.host.
+func envKeyToPropertyKey(envKey string) string {
+	return strings.ToLower(strings.ReplaceAll(envKey, "_", "."))
+}
+
+// CompositeSource merges ConfigResponses fetched from multiple sources,
+// with the same precedence as ConfigResponse.GetPropertySources: sources
+// listed later override properties from sources listed earlier.
+type CompositeSource struct {
+	Sources []ConfigSource
+}
+
+// NewCompositeSource merges sources in the given order.
+func NewCompositeSource(sources ...ConfigSource) *CompositeSource {
+	return &CompositeSource{Sources: sources}
+}
+
+// Fetch implements ConfigSource.
+func (s *CompositeSource) Fetch(ctx context.Context, application, profile, label string) (*ConfigResponse, error) {
+	merged := &ConfigResponse{
+		Name:     application,
+		Profiles: []string{profile},
+		Label:    label,
+	}
+
+	var registries []*DecryptorRegistry
+	for _, source := range s.Sources {
+		configResp, err := source.Fetch(ctx, application, profile, label)
+		if err != nil {
+			return nil, err
+		}
+		merged.PropertySources = append(merged.PropertySources, configResp.PropertySources...)
+		if configResp.decryptors != nil {
+			registries = append(registries, configResp.decryptors)
+		}
+	}
+	merged.decryptors = mergeDecryptorRegistries(registries)
+
+	return merged, nil
+}
+
+// mergeDecryptorRegistries combines registries into one, with the same
+// later-overrides-earlier precedence as PropertySources: if more than one
+// registry registers the same alias, the one from a later source wins.
+// Returns nil if registries is empty.
+func mergeDecryptorRegistries(registries []*DecryptorRegistry) *DecryptorRegistry {
+	if len(registries) == 0 {
+		return nil
+	}
+	if len(registries) == 1 {
+		return registries[0]
+	}
+
+	merged := NewDecryptorRegistry()
+	for _, registry := range registries {
+		registry.mu.RLock()
+		for alias, d := range registry.decryptors {
+			merged.decryptors[alias] = d
+		}
+		registry.mu.RUnlock()
+	}
+	return merged
+}
+
+// decodeSnapshot parses a config snapshot, choosing JSON or YAML based on
+// path's extension.
+func decodeSnapshot(path string, data []byte) (*ConfigResponse, error) {
+	var configResp ConfigResponse
+	if strings.HasSuffix(path, ".json") {
+		if err := json.Unmarshal(data, &configResp); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON config snapshot %q: %w", path, err)
+		}
+		return &configResp, nil
+	}
+	if err := yaml.Unmarshal(data, &configResp); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML config snapshot %q: %w", path, err)
+	}
+	return &configResp, nil
+}
+
+// persistSnapshot writes configResp to path as JSON, for later recovery via
+// loadSnapshot when the config server is unreachable.
+func persistSnapshot(path string, configResp *ConfigResponse) error {
+	data, err := json.MarshalIndent(configResp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write config snapshot %q: %w", path, err)
+	}
+	return nil
+}
+
+// loadSnapshot reads a config snapshot previously written by
+// persistSnapshot.
+func loadSnapshot(path string) (*ConfigResponse, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config snapshot %q: %w", path, err)
+	}
+	var configResp ConfigResponse
+	if err := json.Unmarshal(data, &configResp); err != nil {
+		return nil, fmt.Errorf("failed to parse config snapshot %q: %w", path, err)
+	}
+	return &configResp, nil
+}
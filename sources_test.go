@@ -0,0 +1,183 @@
+package gocloudclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileSourceJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	snapshot := ConfigResponse{
+		Name: "myapp",
+		PropertySources: []PropertySource{
+			{Name: "file", Source: map[string]interface{}{"app.debug": true}},
+		},
+	}
+	data, _ := json.Marshal(snapshot)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	source := NewFileSource(path)
+	config, err := source.Fetch(context.Background(), "myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if debug, exists := config.GetBool("app.debug"); !exists || !debug {
+		t.Errorf("GetBool(app.debug) = %v, %v, want true, true", debug, exists)
+	}
+}
+
+func TestEnvSourceTranslatesKeys(t *testing.T) {
+	t.Setenv("SPRING_DATASOURCE_HOST", "db.internal")
+
+	source := NewEnvSource()
+	config, err := source.Fetch(context.Background(), "myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	host, exists := config.GetString("spring.datasource.host")
+	if !exists || host != "db.internal" {
+		t.Errorf("GetString(spring.datasource.host) = %v, %v, want 'db.internal', true", host, exists)
+	}
+}
+
+func TestCompositeSourcePrecedence(t *testing.T) {
+	base := &staticSource{response: &ConfigResponse{
+		PropertySources: []PropertySource{
+			{Name: "base", Source: map[string]interface{}{"key": "base", "only-base": "x"}},
+		},
+	}}
+	override := &staticSource{response: &ConfigResponse{
+		PropertySources: []PropertySource{
+			{Name: "override", Source: map[string]interface{}{"key": "override"}},
+		},
+	}}
+
+	composite := NewCompositeSource(base, override)
+	config, err := composite.Fetch(context.Background(), "myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	if key, _ := config.GetString("key"); key != "override" {
+		t.Errorf("GetString(key) = %q, want 'override'", key)
+	}
+	if onlyBase, _ := config.GetString("only-base"); onlyBase != "x" {
+		t.Errorf("GetString(only-base) = %q, want 'x'", onlyBase)
+	}
+}
+
+func TestCompositeSourcePropagatesDecryptors(t *testing.T) {
+	registry := NewDecryptorRegistry()
+	registry.Register("", NewAESDecryptor("s3cr3t", []byte("0123456789abcdef")))
+
+	encrypted := &ConfigResponse{
+		PropertySources: []PropertySource{
+			{Name: "remote", Source: map[string]interface{}{"spring.datasource.password": cipherPrefix + "deadbeef"}},
+		},
+	}
+	encrypted.decryptors = registry
+	remote := &staticSource{response: encrypted}
+
+	fallback := &staticSource{response: &ConfigResponse{
+		PropertySources: []PropertySource{
+			{Name: "file", Source: map[string]interface{}{"app.debug": true}},
+		},
+	}}
+
+	composite := NewCompositeSource(remote, fallback)
+	config, err := composite.Fetch(context.Background(), "myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	if config.decryptors == nil {
+		t.Fatal("CompositeSource.Fetch() should propagate a non-nil decryptors registry")
+	}
+}
+
+func TestCompositeSourceMergesDecryptorsFromMultipleSources(t *testing.T) {
+	firstDecryptor := NewAESDecryptor("s3cr3t", []byte("0123456789abcdef"))
+	secondDecryptor := NewAESDecryptor("other-s3cr3t", []byte("fedcba9876543210"))
+
+	firstRegistry := NewDecryptorRegistry()
+	firstRegistry.Register("db", firstDecryptor)
+	first := &ConfigResponse{PropertySources: []PropertySource{{Name: "remote-a", Source: map[string]interface{}{}}}}
+	first.decryptors = firstRegistry
+
+	secondRegistry := NewDecryptorRegistry()
+	secondRegistry.Register("cache", secondDecryptor)
+	second := &ConfigResponse{PropertySources: []PropertySource{{Name: "remote-b", Source: map[string]interface{}{}}}}
+	second.decryptors = secondRegistry
+
+	composite := NewCompositeSource(&staticSource{response: first}, &staticSource{response: second})
+	config, err := composite.Fetch(context.Background(), "myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	if _, ok := config.decryptors.Get("db"); !ok {
+		t.Error("merged registry should still resolve alias 'db' from the first source")
+	}
+	if _, ok := config.decryptors.Get("cache"); !ok {
+		t.Error("merged registry should resolve alias 'cache' from the second source")
+	}
+}
+
+type staticSource struct {
+	response *ConfigResponse
+}
+
+func (s *staticSource) Fetch(ctx context.Context, application, profile, label string) (*ConfigResponse, error) {
+	return s.response, nil
+}
+
+func TestWithFallbackServesStaleSnapshotWhenUnreachable(t *testing.T) {
+	up := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(ConfigResponse{
+			Name: "myapp",
+			PropertySources: []PropertySource{
+				{Name: "application.yml", Source: map[string]interface{}{"app.debug": true}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.WithFallback(filepath.Join(t.TempDir(), "snapshot.json"))
+
+	config, err := client.GetConfig("myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("initial GetConfig() error = %v", err)
+	}
+	if config.Stale {
+		t.Error("initial GetConfig() should not be marked Stale")
+	}
+
+	up = false
+	config, err = client.GetConfig("myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("fallback GetConfig() error = %v", err)
+	}
+	if !config.Stale {
+		t.Error("GetConfig() after the server goes down should be marked Stale")
+	}
+	if debug, exists := config.GetBool("app.debug"); !exists || !debug {
+		t.Errorf("fallback GetBool(app.debug) = %v, %v, want true, true", debug, exists)
+	}
+}
@@ -0,0 +1,235 @@
+package gocloudclient
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ConfigChangeEvent describes a configuration change detected between two
+// fetches of the same application/profile/label.
+type ConfigChangeEvent struct {
+	Previous  *ConfigResponse
+	Current   *ConfigResponse
+	Added     map[string]interface{}
+	Removed   map[string]interface{}
+	Changed   map[string]interface{}
+	FetchedAt time.Time
+}
+
+// Watch polls GetConfigContext every interval and emits a ConfigChangeEvent
+// on the returned channel whenever the server reports a new Version/State,
+// falling back to a content hash of PropertySources when neither is set.
+// The channel is closed when ctx is done.
+func (c *AppClient) Watch(ctx context.Context, application, profile, label string, interval time.Duration) (<-chan ConfigChangeEvent, error) {
+	previous, err := c.GetConfigContext(ctx, application, profile, label)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan ConfigChangeEvent)
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current, err := c.GetConfigContext(ctx, application, profile, label)
+			if err != nil {
+				continue
+			}
+			if !configChanged(previous, current) {
+				continue
+			}
+
+			event := newConfigChangeEvent(previous, current)
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+			previous = current
+		}
+	}()
+
+	return events, nil
+}
+
+// WatchSSE subscribes to the config server's /monitor SSE stream
+// (Spring Cloud Bus-style) and re-fetches configuration whenever a
+// notification arrives, emitting a ConfigChangeEvent for each observed
+// change. The channel is closed when ctx is done or the stream ends.
+func (c *AppClient) WatchSSE(ctx context.Context, application, profile, label string) (<-chan ConfigChangeEvent, error) {
+	previous, err := c.GetConfigContext(ctx, application, profile, label)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/monitor", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SSE request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if err := c.authenticate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SSE stream: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("SSE server returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	events := make(chan ConfigChangeEvent)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			if !strings.HasPrefix(scanner.Text(), "data:") {
+				continue
+			}
+
+			current, err := c.GetConfigContext(ctx, application, profile, label)
+			if err != nil {
+				continue
+			}
+			if !configChanged(previous, current) {
+				continue
+			}
+
+			event := newConfigChangeEvent(previous, current)
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+			previous = current
+		}
+	}()
+
+	return events, nil
+}
+
+// configChanged reports whether current differs from previous, preferring
+// the server-reported Version/State and falling back to a content hash of
+// PropertySources when the server doesn't populate either.
+func configChanged(previous, current *ConfigResponse) bool {
+	if current.Version != "" || current.State != "" {
+		return current.Version != previous.Version || current.State != previous.State
+	}
+	return configHash(current) != configHash(previous)
+}
+
+// configHash returns a content hash of a ConfigResponse's PropertySources.
+func configHash(config *ConfigResponse) string {
+	data, err := json.Marshal(config.PropertySources)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// newConfigChangeEvent diffs the flattened property sources of previous and
+// current into added/removed/changed maps.
+func newConfigChangeEvent(previous, current *ConfigResponse) ConfigChangeEvent {
+	prevProps := previous.GetPropertySources()
+	currProps := current.GetPropertySources()
+
+	added := make(map[string]interface{})
+	removed := make(map[string]interface{})
+	changed := make(map[string]interface{})
+
+	for k, v := range currProps {
+		old, existed := prevProps[k]
+		if !existed {
+			added[k] = v
+		} else if !reflect.DeepEqual(old, v) {
+			changed[k] = v
+		}
+	}
+	for k, v := range prevProps {
+		if _, stillExists := currProps[k]; !stillExists {
+			removed[k] = v
+		}
+	}
+
+	return ConfigChangeEvent{
+		Previous:  previous,
+		Current:   current,
+		Added:     added,
+		Removed:   removed,
+		Changed:   changed,
+		FetchedAt: time.Now(),
+	}
+}
+
+// WatchAndInvalidate starts a Watch on the underlying AppClient and
+// invalidates the cache entry for application/profile/label whenever a
+// ConfigChangeEvent is observed, so the next GetConfig call re-fetches
+// fresh data instead of serving the stale cached entry.
+func (c *CachedClient) WatchAndInvalidate(ctx context.Context, application, profile, label string, interval time.Duration) (<-chan ConfigChangeEvent, error) {
+	events, err := c.client.Watch(ctx, application, profile, label, interval)
+	if err != nil {
+		return nil, err
+	}
+	return c.invalidateOn(ctx, application, profile, label, events), nil
+}
+
+// WatchSSEAndInvalidate starts a WatchSSE on the underlying AppClient and
+// invalidates the cache entry for application/profile/label whenever a
+// ConfigChangeEvent is observed, the same way WatchAndInvalidate does for
+// polling-based Watch.
+func (c *CachedClient) WatchSSEAndInvalidate(ctx context.Context, application, profile, label string) (<-chan ConfigChangeEvent, error) {
+	events, err := c.client.WatchSSE(ctx, application, profile, label)
+	if err != nil {
+		return nil, err
+	}
+	return c.invalidateOn(ctx, application, profile, label, events), nil
+}
+
+// invalidateOn relays events to out, invalidating the cache entry for
+// application/profile/label as each one arrives.
+func (c *CachedClient) invalidateOn(ctx context.Context, application, profile, label string, events <-chan ConfigChangeEvent) <-chan ConfigChangeEvent {
+	out := make(chan ConfigChangeEvent)
+	go func() {
+		defer close(out)
+		for event := range events {
+			c.InvalidateCache(application, profile, label)
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
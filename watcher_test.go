@@ -0,0 +1,235 @@
+package gocloudclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newSSEConfigServer serves application/profile/label config fetches the
+// same way the Watch tests' stub servers do, switching to "db.internal"
+// once fetchCount exceeds changeAfter, and serves a single SSE
+// notification on /monitor once notify is closed.
+func newSSEConfigServer(notify <-chan struct{}, changeAfter int32) *httptest.Server {
+	var fetchCount int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/monitor", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+		flusher.Flush()
+
+		<-notify
+		fmt.Fprint(w, "data: refresh\n\n")
+		flusher.Flush()
+
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&fetchCount, 1)
+		host := "localhost"
+		if count > changeAfter {
+			host = "db.internal"
+		}
+		json.NewEncoder(w).Encode(ConfigResponse{
+			Name: "myapp",
+			PropertySources: []PropertySource{
+				{Name: "application.yml", Source: map[string]interface{}{
+					"spring.datasource.host": host,
+				}},
+			},
+		})
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestWatchEmitsEventOnChange(t *testing.T) {
+	var fetchCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&fetchCount, 1)
+		host := "localhost"
+		if count > 1 {
+			host = "db.internal"
+		}
+		json.NewEncoder(w).Encode(ConfigResponse{
+			Name: "myapp",
+			PropertySources: []PropertySource{
+				{Name: "application.yml", Source: map[string]interface{}{
+					"spring.datasource.host": host,
+				}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	events, err := client.Watch(ctx, "myapp", "dev", "master", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("events channel closed before emitting a change")
+		}
+		if event.Changed["spring.datasource.host"] != "db.internal" {
+			t.Errorf("Changed[spring.datasource.host] = %v, want 'db.internal'", event.Changed["spring.datasource.host"])
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for a change event")
+	}
+}
+
+func TestWatchAndInvalidate(t *testing.T) {
+	var fetchCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&fetchCount, 1)
+		host := "localhost"
+		if count > 2 {
+			host = "db.internal"
+		}
+		json.NewEncoder(w).Encode(ConfigResponse{
+			Name: "myapp",
+			PropertySources: []PropertySource{
+				{Name: "application.yml", Source: map[string]interface{}{
+					"spring.datasource.host": host,
+				}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	cachedClient := NewCachedClient(client, time.Hour)
+
+	config, err := cachedClient.GetConfig("myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if host, _ := config.GetString("spring.datasource.host"); host != "localhost" {
+		t.Fatalf("initial GetConfig() host = %q, want 'localhost'", host)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	events, err := cachedClient.WatchAndInvalidate(ctx, "myapp", "dev", "master", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchAndInvalidate() error = %v", err)
+	}
+
+	select {
+	case _, ok := <-events:
+		if !ok {
+			t.Fatal("events channel closed before emitting a change")
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for a change event")
+	}
+
+	config, err = cachedClient.GetConfig("myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if host, _ := config.GetString("spring.datasource.host"); host != "db.internal" {
+		t.Errorf("GetConfig() after invalidation host = %q, want 'db.internal'", host)
+	}
+}
+
+func TestWatchSSEEmitsEventOnChange(t *testing.T) {
+	notify := make(chan struct{})
+	server := newSSEConfigServer(notify, 1) // WatchSSE's own baseline fetch is count 1
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events, err := client.WatchSSE(ctx, "myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("WatchSSE() error = %v", err)
+	}
+
+	close(notify)
+
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("events channel closed before emitting a change")
+		}
+		if event.Changed["spring.datasource.host"] != "db.internal" {
+			t.Errorf("Changed[spring.datasource.host] = %v, want 'db.internal'", event.Changed["spring.datasource.host"])
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for a change event")
+	}
+}
+
+func TestWatchSSEAndInvalidate(t *testing.T) {
+	notify := make(chan struct{})
+	// count 1: cachedClient.GetConfig's initial fetch. count 2: WatchSSE's
+	// own baseline fetch. Only fetches after that should see the change.
+	server := newSSEConfigServer(notify, 2)
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	cachedClient := NewCachedClient(client, time.Hour)
+
+	config, err := cachedClient.GetConfig("myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if host, _ := config.GetString("spring.datasource.host"); host != "localhost" {
+		t.Fatalf("initial GetConfig() host = %q, want 'localhost'", host)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events, err := cachedClient.WatchSSEAndInvalidate(ctx, "myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("WatchSSEAndInvalidate() error = %v", err)
+	}
+
+	close(notify)
+
+	select {
+	case _, ok := <-events:
+		if !ok {
+			t.Fatal("events channel closed before emitting a change")
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for a change event")
+	}
+
+	config, err = cachedClient.GetConfig("myapp", "dev", "master")
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if host, _ := config.GetString("spring.datasource.host"); host != "db.internal" {
+		t.Errorf("GetConfig() after invalidation host = %q, want 'db.internal'", host)
+	}
+}